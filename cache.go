@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"hash"
 	"log"
+	"sync"
 	"time"
 
 	inMemCLib "github.com/dgraph-io/ristretto"
 	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/singleflight"
 )
 
 // HybridCache is a cache that will use multiple cache sources
@@ -20,6 +22,36 @@ type HybridCache struct {
 	Redis           *redis.Client
 	ExpiresInMemory time.Duration
 	ExpiresRedis    time.Duration
+	Codec           Codec
+
+	// stores is the ordered chain of cache tiers (L1, L2, …) that Set/Get/Del
+	// operate on. When unset (e.g. a HybridCache built as a struct literal,
+	// as the tests do), effectiveStores derives it from InMemoryCache/Redis
+	// so the legacy two-tier composition keeps working. Set/SetWithTTL pass
+	// their expiration to every tier, including the in-memory one, which
+	// uses ExpiresInMemory only for backfills and zero-expiration writes.
+	stores []Store
+
+	// Invalidation bus wiring; see HybridCacheOption.InvalidationChannel.
+	pubsub              PubSub
+	invalidationChannel string
+	publisherID         string
+	cancelInvalidation  context.CancelFunc
+
+	// NegativeTTL, when set, makes GetOrLoad cache a short-lived negative
+	// marker on load errors so repeated misses don't each re-invoke loader.
+	NegativeTTL time.Duration
+	// SoftTTL, when set and smaller than a GetOrLoad call's ttl, enables
+	// early refresh: once an entry's age passes SoftTTL, GetOrLoad returns
+	// the stale value immediately and refreshes it in the background.
+	SoftTTL time.Duration
+
+	loadGroupOnce     sync.Once
+	loadGroupInstance *singleflight.Group
+
+	// Observer receives hit/miss/set/del/load lifecycle callbacks; see the
+	// Observer doc comment. Defaults to a no-op.
+	Observer Observer
 }
 
 type HybridCacheOption struct {
@@ -28,41 +60,144 @@ type HybridCacheOption struct {
 	Prefix          string
 	Redis           *redis.Client
 	MaxCost         int64
+	// Codec is used by TypedCache to (de)serialize values. Defaults to JSONCodec.
+	Codec Codec
+	// Stores overrides the default ristretto+Redis composition with an
+	// arbitrary ordered chain of cache tiers, e.g.
+	// []Store{NewRistrettoStore(...), NewBigcacheStore(...), NewRedisClusterStore(...)}.
+	// When set, no ristretto cache is allocated, so InMemoryCache on the
+	// resulting HybridCache is left nil (Redis is still set to whatever
+	// Redis field was passed, if any — it isn't implied by Stores); use the
+	// Store-returning constructors instead of GetFromInMemoryCache/
+	// GetFromRedis to inspect individual tiers.
+	Stores []Store
+	// InvalidationChannel, when non-empty, enables cross-process L1
+	// coherence: Set/Del/DeleteKeysByPatternFromRedis publish an
+	// invalidation message on this channel, and every HybridCache
+	// subscribed to it evicts the matching key(s) from its local L1.
+	InvalidationChannel string
+	// PubSub overrides the default Redis-backed invalidation bus, e.g. with
+	// NewInProcPubSub() in tests or a NATS-backed implementation. Defaults
+	// to NewRedisPubSub(option.Redis).
+	PubSub PubSub
+	// NegativeTTL and SoftTTL configure GetOrLoad; see their doc comments
+	// on HybridCache.
+	NegativeTTL time.Duration
+	SoftTTL     time.Duration
+	// Observer, when set, receives lifecycle callbacks for metrics/tracing.
+	// See the Observer doc comment; PrometheusObserver is a ready-made
+	// implementation.
+	Observer Observer
 }
 
 // Initialize a new HybridCache
 func NewHybridCache(option HybridCacheOption) *HybridCache {
-	// inMemCLib
-	maxCost := option.MaxCost
-	if maxCost == 0 {
-		maxCost = 100
-	}
-	cache, err := inMemCLib.NewCache(&inMemCLib.Config{
-		MaxCost:     maxCost,
-		NumCounters: maxCost * 10,
-		BufferItems: 64,
-	})
-	if err != nil {
-		log.Fatalf("Error creating ristretto cache: %v", err)
+	codec := option.Codec
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	observer := option.Observer
+	if observer == nil {
+		observer = noopObserver{}
+	}
+
+	// InMemoryCache is only populated for the default two-tier composition,
+	// matching the HybridCacheOption.Stores doc comment: a custom Stores
+	// chain that doesn't use ristretto shouldn't pay for one. Redis is always
+	// set from option.Redis.
+	var cache *inMemCLib.Cache
+	stores := option.Stores
+	if len(stores) == 0 {
+		maxCost := option.MaxCost
+		if maxCost == 0 {
+			maxCost = 100
+		}
+		var err error
+		cache, err = inMemCLib.NewCache(&inMemCLib.Config{
+			MaxCost:     maxCost,
+			NumCounters: maxCost * 10,
+			BufferItems: 64,
+		})
+		if err != nil {
+			log.Fatalf("Error creating ristretto cache: %v", err)
+		}
+
+		redisStore := NewRedisStore(option.Redis)
+		redisStore.Observer = observer
+		stores = []Store{
+			NewRistrettoStore(cache, option.ExpiresInMemory),
+			redisStore,
+		}
 	}
-	return &HybridCache{
+
+	hc := &HybridCache{
 		InMemoryCache:   cache,
 		Redis:           option.Redis,
 		Prefix:          option.Prefix,
 		ExpiresInMemory: option.ExpiresInMemory,
-		ExpiresRedis:    option.ExpiresRedis}
+		ExpiresRedis:    option.ExpiresRedis,
+		Codec:           codec,
+		stores:          stores,
+		NegativeTTL:     option.NegativeTTL,
+		SoftTTL:         option.SoftTTL,
+		Observer:        observer}
+
+	if option.InvalidationChannel != "" {
+		pubsub := option.PubSub
+		if pubsub == nil {
+			pubsub = NewRedisPubSub(option.Redis)
+		}
+		hc.pubsub = pubsub
+		hc.invalidationChannel = option.InvalidationChannel
+		hc.publisherID = newPublisherID()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		hc.cancelInvalidation = cancel
+		hc.listenForInvalidations(ctx)
+	}
+
+	return hc
+}
+
+// effectiveStores returns the configured tier chain, falling back to the
+// legacy InMemoryCache+Redis composition when stores was never set (e.g. a
+// HybridCache built as a struct literal).
+func (hc *HybridCache) effectiveStores() []Store {
+	if len(hc.stores) > 0 {
+		return hc.stores
+	}
+	redisStore := NewRedisStore(hc.Redis)
+	redisStore.Observer = hc.observer()
+	return []Store{
+		NewRistrettoStore(hc.InMemoryCache, hc.ExpiresInMemory),
+		redisStore,
+	}
 }
 
 // Set value in cache
 func (hc *HybridCache) Set(key string, value []byte) {
-	hc.SetInMemoryCache(key, value)
-	hc.SetInRedis(context.Background(), key, value, hc.ExpiresRedis)
+	hc.SetCtx(context.Background(), key, value)
+}
+
+// SetCtx sets value in cache, propagating ctx to the Redis call
+func (hc *HybridCache) SetCtx(ctx context.Context, key string, value []byte) {
+	hc.SetWithTTLCtx(ctx, key, value, hc.ExpiresRedis)
 }
 
 // SetWithTTL set value in cache with expiration
 func (hc *HybridCache) SetWithTTL(key string, value []byte, expiration time.Duration) {
-	hc.SetInMemoryCache(key, value)
-	hc.SetInRedis(context.Background(), key, value, expiration)
+	hc.SetWithTTLCtx(context.Background(), key, value, expiration)
+}
+
+// SetWithTTLCtx is the context-aware core of SetWithTTL. It writes through
+// every configured tier (see effectiveStores).
+func (hc *HybridCache) SetWithTTLCtx(ctx context.Context, key string, value []byte, expiration time.Duration) {
+	for _, store := range hc.effectiveStores() {
+		store.Set(ctx, key, value, expiration)
+	}
+	hc.observer().OnSet(key)
+	hc.publishInvalidation(ctx, invalidationMessage{Key: key})
 }
 
 // Set value in in-memory cache
@@ -78,14 +213,27 @@ func (rc *HybridCache) SetInRedis(ctx context.Context, key string, value []byte,
 
 // get value from cache
 func (hc *HybridCache) Get(key string) ([]byte, bool) {
-	value, found := hc.GetFromInMemoryCache(key)
-	if !found {
-		value, found = hc.GetFromRedis(context.Background(), key)
-		if found {
-			hc.SetInMemoryCache(key, value)
+	return hc.GetCtx(context.Background(), key)
+}
+
+// GetCtx is the context-aware core of Get. Tiers are checked in order; a hit
+// on a later tier is backfilled into every earlier tier (see
+// effectiveStores).
+func (hc *HybridCache) GetCtx(ctx context.Context, key string) ([]byte, bool) {
+	stores := hc.effectiveStores()
+	for i, store := range stores {
+		value, found := store.Get(ctx, key)
+		if !found {
+			continue
+		}
+		hc.observer().OnHit(store.Name(), key)
+		for _, earlier := range stores[:i] {
+			earlier.Set(ctx, key, value, 0)
 		}
+		return value, true
 	}
-	return value, found
+	hc.observer().OnMiss(key)
+	return nil, false
 }
 
 // get value from in memory cache
@@ -108,8 +256,17 @@ func (rc *HybridCache) GetFromRedis(ctx context.Context, key string) ([]byte, bo
 
 // del value from cache
 func (hc *HybridCache) Del(key string) {
-	hc.DelFromInMemoryCache(key)
-	hc.DelFromRedis(context.Background(), key)
+	hc.DelCtx(context.Background(), key)
+}
+
+// DelCtx is the context-aware core of Del. It removes key from every
+// configured tier (see effectiveStores).
+func (hc *HybridCache) DelCtx(ctx context.Context, key string) {
+	for _, store := range hc.effectiveStores() {
+		store.Del(ctx, key)
+	}
+	hc.observer().OnDel(key)
+	hc.publishInvalidation(ctx, invalidationMessage{Key: key})
 }
 
 // del value from im memory cache
@@ -140,46 +297,33 @@ func (hc *HybridCache) DelMultipleKeysFromRedis(ctx context.Context, keys []stri
 	return nil
 }
 
-// DeleteKeysByPatternFromRedis deletes all keys matching the provided pattern from Redis.
+// DeleteKeysByPatternFromRedis deletes all keys matching the provided
+// pattern from every configured tier (see effectiveStores), not just Redis
+// despite the name, which is kept for backwards compatibility. Tiers that
+// return ErrScanNotSupported (e.g. ristretto, which keeps no enumerable key
+// index) fall back to Clear(), the same as invalidateLocal; a tier
+// supporting neither is logged and left untouched.
 func (hc *HybridCache) DeleteKeysByPatternFromRedis(ctx context.Context, pattern string) error {
-	keys := []string{}
-	cursor := uint64(0)
-
-	for {
-		result := hc.Redis.Scan(ctx, cursor, pattern, 100)
-		scannedKeys, nextCursor, err := result.Result()
-		if err != nil {
+	for _, store := range hc.effectiveStores() {
+		keys, err := store.Scan(ctx, pattern)
+		switch {
+		case err == ErrScanNotSupported:
+			if clearErr := store.Clear(ctx); clearErr == ErrClearNotSupported {
+				log.Printf("cache: store %q supports neither Scan nor Clear; pattern %q left stale in that tier", store.Name(), pattern)
+			} else if clearErr != nil {
+				log.Printf("cache: failed to clear store %q for pattern %q: %v", store.Name(), pattern, clearErr)
+			}
+		case err != nil:
 			return err
+		default:
+			for _, key := range keys {
+				store.Del(ctx, key)
+			}
 		}
-
-		// Accumulate the keys found
-		keys = append(keys, scannedKeys...)
-
-		// Update the cursor for the next iteration
-		cursor = nextCursor
-
-		// If cursor is 0, we are done scanning
-		if cursor == 0 {
-			break
-		}
-	}
-
-	// If no keys found, return nil
-	if len(keys) == 0 {
-		return nil
-	}
-
-	// Delete keys found by pattern using a pipeline
-	pipe := hc.Redis.Pipeline()
-	for _, key := range keys {
-		pipe.Del(ctx, key)
-	}
-
-	_, err := pipe.Exec(ctx)
-	if err != nil {
-		return err
 	}
 
+	hc.observer().OnScan(pattern)
+	hc.publishInvalidation(ctx, invalidationMessage{Pattern: pattern})
 	return nil
 }
 
@@ -192,7 +336,16 @@ func (hc *HybridCache) GetCacheKey(data string) string {
 	return fmt.Sprintf("%s:%s", hc.Prefix, hex.EncodeToString(hasher.Sum(nil)))
 }
 
-// Close the cache
+// Close the cache, unsubscribing from the invalidation bus if one was
+// configured.
 func (hc *HybridCache) Close() {
-	hc.InMemoryCache.Close()
+	if hc.cancelInvalidation != nil {
+		hc.cancelInvalidation()
+	}
+	if hc.pubsub != nil {
+		hc.pubsub.Close()
+	}
+	if hc.InMemoryCache != nil {
+		hc.InMemoryCache.Close()
+	}
 }
@@ -11,17 +11,24 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func setupTestCache() *HybridCache {
-	redisClient := redis.NewClient(&redis.Options{
+func newTestRedisClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
 		Addr:     os.Getenv("REDIS_HOST"),
 		Password: os.Getenv("REDIS_PASSWORD"),
 	})
+}
 
-	cache, _ := ristretto.NewCache(&ristretto.Config{
+func newTestRistretto() (*ristretto.Cache, error) {
+	return ristretto.NewCache(&ristretto.Config{
 		MaxCost:     1000,
 		NumCounters: 10000,
 		BufferItems: 64,
 	})
+}
+
+func setupTestCache() *HybridCache {
+	redisClient := newTestRedisClient()
+	cache, _ := newTestRistretto()
 
 	return &HybridCache{
 		InMemoryCache:   cache,
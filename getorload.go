@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// loadEnvelope is the on-the-wire format used by GetOrLoad to carry enough
+// metadata to support negative caching and early refresh. Plain Set/Get
+// callers are unaffected: they keep storing raw bytes, with no envelope.
+type loadEnvelope struct {
+	Value    []byte `json:"v,omitempty"`
+	Negative bool   `json:"n,omitempty"`
+	StoredAt int64  `json:"t"`
+	HardTTL  int64  `json:"h"`
+	SoftTTL  int64  `json:"s,omitempty"`
+}
+
+func (e loadEnvelope) expired(now time.Time) bool {
+	return now.After(time.Unix(0, e.StoredAt).Add(time.Duration(e.HardTTL)))
+}
+
+func (e loadEnvelope) softExpired(now time.Time) bool {
+	if e.SoftTTL == 0 {
+		return false
+	}
+	return now.After(time.Unix(0, e.StoredAt).Add(time.Duration(e.SoftTTL)))
+}
+
+// GetOrLoad returns the cached value for key, coalescing concurrent misses
+// so loader runs at most once per key at a time (via singleflight). On a
+// load error it optionally caches a short-TTL negative marker (see
+// HybridCacheOption.NegativeTTL) so repeated misses against a failing
+// upstream don't each re-invoke loader. When HybridCacheOption.SoftTTL is
+// set and a hit's age has passed it (but not the hard ttl), the stale value
+// is returned immediately and a refresh is kicked off in the background.
+func (hc *HybridCache) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	ctx, span := tracer.Start(ctx, "cache.GetOrLoad")
+	span.SetAttributes(spanKeyAttribute(key, false))
+	defer span.End()
+
+	if env, found := hc.getEnvelope(ctx, key); found {
+		if env.Negative {
+			return nil, ErrNotFound
+		}
+		if env.softExpired(time.Now()) {
+			go hc.refresh(key, ttl, loader)
+		}
+		return env.Value, nil
+	}
+
+	value, err, _ := hc.loadGroup().Do(key, func() (interface{}, error) {
+		return hc.load(ctx, key, ttl, loader)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.([]byte), nil
+}
+
+// load invokes loader, storing its result (or a negative marker on error)
+// before returning.
+func (hc *HybridCache) load(ctx context.Context, key string, ttl time.Duration, loader func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	start := time.Now()
+	value, err := loader(ctx)
+	hc.observer().OnLoad(key, time.Since(start), err)
+	if err != nil {
+		if hc.NegativeTTL > 0 {
+			hc.setEnvelope(ctx, key, loadEnvelope{Negative: true, StoredAt: time.Now().UnixNano(), HardTTL: int64(hc.NegativeTTL)}, hc.NegativeTTL)
+		}
+		return nil, err
+	}
+	hc.setEnvelope(ctx, key, loadEnvelope{
+		Value:    value,
+		StoredAt: time.Now().UnixNano(),
+		HardTTL:  int64(ttl),
+		SoftTTL:  int64(hc.SoftTTL),
+	}, ttl)
+	return value, nil
+}
+
+// refresh reloads key in the background, coalesced with any concurrent
+// GetOrLoad miss through the same singleflight group.
+func (hc *HybridCache) refresh(key string, ttl time.Duration, loader func(ctx context.Context) ([]byte, error)) {
+	ctx := context.Background()
+	hc.loadGroup().Do(key, func() (interface{}, error) {
+		return hc.load(ctx, key, ttl, loader)
+	})
+}
+
+func (hc *HybridCache) getEnvelope(ctx context.Context, key string) (loadEnvelope, bool) {
+	data, found := hc.GetCtx(ctx, key)
+	if !found {
+		return loadEnvelope{}, false
+	}
+	var env loadEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return loadEnvelope{}, false
+	}
+	if env.expired(time.Now()) {
+		return loadEnvelope{}, false
+	}
+	return env, true
+}
+
+func (hc *HybridCache) setEnvelope(ctx context.Context, key string, env loadEnvelope, ttl time.Duration) {
+	data, err := json.Marshal(env)
+	if err != nil {
+		return
+	}
+	hc.SetWithTTLCtx(ctx, key, data, ttl)
+}
+
+// loadGroup lazily initializes the singleflight group used to coalesce
+// concurrent GetOrLoad misses, so zero-value and struct-literal HybridCaches
+// (as the tests build) work without going through NewHybridCache.
+func (hc *HybridCache) loadGroup() *singleflight.Group {
+	hc.loadGroupOnce.Do(func() {
+		hc.loadGroupInstance = &singleflight.Group{}
+	})
+	return hc.loadGroupInstance
+}
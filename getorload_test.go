@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrLoadCoalescesConcurrentMisses(t *testing.T) {
+	cache := setupTestCache()
+	defer cache.Close()
+
+	key := "getorload:coalesce"
+	var loads int32
+
+	loader := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		time.Sleep(50 * time.Millisecond)
+		return []byte("loaded"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.GetOrLoad(context.Background(), key, 5*time.Minute, loader)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("loaded"), value)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+}
+
+func TestGetOrLoadNegativeCaching(t *testing.T) {
+	cache := setupTestCache()
+	cache.NegativeTTL = time.Minute
+	defer cache.Close()
+
+	key := "getorload:negative"
+	var loads int32
+	failingLoader := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&loads, 1)
+		return nil, errors.New("upstream down")
+	}
+
+	_, err := cache.GetOrLoad(context.Background(), key, 5*time.Minute, failingLoader)
+	assert.Error(t, err)
+
+	// The negative marker should suppress a second load attempt.
+	_, err = cache.GetOrLoad(context.Background(), key, 5*time.Minute, failingLoader)
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&loads))
+}
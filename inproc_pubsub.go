@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"sync"
+)
+
+// InProcPubSub is a PubSub implementation that fans messages out to
+// subscribers within the same process. It requires no external broker,
+// which makes it useful for tests and for exercising the invalidation bus
+// in a single-process deployment.
+type InProcPubSub struct {
+	mu          sync.Mutex
+	subscribers map[string][]chan []byte
+}
+
+// NewInProcPubSub creates an empty in-process PubSub.
+func NewInProcPubSub() *InProcPubSub {
+	return &InProcPubSub{subscribers: make(map[string][]chan []byte)}
+}
+
+func (ps *InProcPubSub) Publish(_ context.Context, channel string, payload []byte) error {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	for _, sub := range ps.subscribers[channel] {
+		select {
+		case sub <- payload:
+		default:
+			// Slow subscriber; drop rather than block the publisher.
+		}
+	}
+	return nil
+}
+
+func (ps *InProcPubSub) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	out := make(chan []byte, 16)
+
+	ps.mu.Lock()
+	ps.subscribers[channel] = append(ps.subscribers[channel], out)
+	ps.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		subs := ps.subscribers[channel]
+		for i, sub := range subs {
+			if sub == out {
+				ps.subscribers[channel] = append(subs[:i], subs[i+1:]...)
+				break
+			}
+		}
+		close(out)
+	}()
+
+	return out, nil
+}
+
+func (ps *InProcPubSub) Close() error {
+	return nil
+}
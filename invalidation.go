@@ -0,0 +1,107 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+)
+
+// invalidationMessage is published on HybridCacheOption.InvalidationChannel
+// whenever a writer mutates a key or a pattern, so peer processes sharing
+// the same backing store can evict the stale entry from their local L1.
+// Exactly one of Key/Pattern is set.
+type invalidationMessage struct {
+	Key         string `json:"key,omitempty"`
+	Pattern     string `json:"pattern,omitempty"`
+	PublisherID string `json:"publisher_id"`
+}
+
+// newPublisherID returns a random identifier, generated once per
+// HybridCache, used so a HybridCache can ignore invalidation messages it
+// published itself.
+func newPublisherID() string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// publishInvalidation broadcasts msg on the invalidation bus, if configured.
+func (hc *HybridCache) publishInvalidation(ctx context.Context, msg invalidationMessage) {
+	if hc.pubsub == nil {
+		return
+	}
+	msg.PublisherID = hc.publisherID
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		log.Printf("cache: failed to marshal invalidation message: %v", err)
+		return
+	}
+	if err := hc.pubsub.Publish(ctx, hc.invalidationChannel, payload); err != nil {
+		log.Printf("cache: failed to publish invalidation message: %v", err)
+	}
+}
+
+// listenForInvalidations subscribes to the invalidation channel and evicts
+// matching keys from the local L1 until ctx is canceled. Messages published
+// by this same HybridCache are ignored; those from other HybridCaches in the
+// same process are not.
+func (hc *HybridCache) listenForInvalidations(ctx context.Context) {
+	ch, err := hc.pubsub.Subscribe(ctx, hc.invalidationChannel)
+	if err != nil {
+		log.Printf("cache: failed to subscribe to invalidation channel %q: %v", hc.invalidationChannel, err)
+		return
+	}
+	go func() {
+		for payload := range ch {
+			var msg invalidationMessage
+			if err := json.Unmarshal(payload, &msg); err != nil {
+				continue
+			}
+			if msg.PublisherID == hc.publisherID {
+				continue
+			}
+			hc.invalidateLocal(msg)
+		}
+	}()
+}
+
+// invalidateLocal drops key/pattern from the local L1 tier only; the
+// lower tiers remain the source of truth and are left untouched.
+func (hc *HybridCache) invalidateLocal(msg invalidationMessage) {
+	stores := hc.effectiveStores()
+	if len(stores) == 0 {
+		return
+	}
+	l1 := stores[0]
+	ctx := context.Background()
+
+	if msg.Key != "" {
+		l1.Del(ctx, msg.Key)
+		return
+	}
+	if msg.Pattern == "" {
+		return
+	}
+
+	keys, err := l1.Scan(ctx, msg.Pattern)
+	switch {
+	case err == ErrScanNotSupported:
+		// L1 can't enumerate its keys (e.g. ristretto); clearing it
+		// entirely is the only way to guarantee the pattern is evicted.
+		if clearErr := l1.Clear(ctx); clearErr == ErrClearNotSupported {
+			log.Printf("cache: L1 store %q supports neither Scan nor Clear; pattern %q left stale in local tier", l1.Name(), msg.Pattern)
+		} else if clearErr != nil {
+			log.Printf("cache: failed to clear L1 for pattern %q: %v", msg.Pattern, clearErr)
+		}
+	case err != nil:
+		log.Printf("cache: failed to scan L1 for pattern %q: %v", msg.Pattern, err)
+	default:
+		for _, key := range keys {
+			l1.Del(ctx, key)
+		}
+	}
+}
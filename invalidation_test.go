@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+)
+
+func newInvalidationNode(t *testing.T, addr string) *HybridCache {
+	t.Helper()
+	return NewHybridCache(HybridCacheOption{
+		Redis: redis.NewClient(&redis.Options{
+			Addr: addr,
+		}),
+		ExpiresInMemory:     5 * time.Minute,
+		ExpiresRedis:        10 * time.Minute,
+		Prefix:              "test",
+		InvalidationChannel: "test:invalidations",
+	})
+}
+
+func TestInvalidationBusEvictsPeerL1(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	nodeA := newInvalidationNode(t, server.Addr())
+	defer nodeA.Close()
+	nodeB := newInvalidationNode(t, server.Addr())
+	defer nodeB.Close()
+
+	// Let the subscriptions establish before publishing.
+	time.Sleep(50 * time.Millisecond)
+
+	key := "shared-key"
+	value := []byte("v1")
+
+	nodeA.Set(key, value)
+
+	// nodeB reads through to Redis once, populating its own L1.
+	got, found := nodeB.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, value, got)
+
+	// nodeA writes a new value, which should publish an invalidation that
+	// evicts the stale entry from nodeB's L1.
+	newValue := []byte("v2")
+	nodeA.Set(key, newValue)
+
+	assert.Eventually(t, func() bool {
+		_, found := nodeB.GetFromInMemoryCache(key)
+		return !found
+	}, 2*time.Second, 10*time.Millisecond)
+
+	// nodeB should now read the fresh value back through Redis.
+	got, found = nodeB.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, newValue, got)
+}
@@ -0,0 +1,45 @@
+package cache
+
+import "time"
+
+// Observer receives lifecycle callbacks from HybridCache so applications
+// can wire up metrics and tracing without HybridCache depending on any
+// specific backend. It is opt-in via HybridCacheOption.Observer; the
+// default noopObserver costs nothing.
+type Observer interface {
+	// OnHit fires when a Get is served from tier (the Store's Name()).
+	OnHit(tier, key string)
+	// OnMiss fires when a Get finds key in no tier.
+	OnMiss(key string)
+	// OnSet fires after a Set/SetWithTTL writes through every tier.
+	OnSet(key string)
+	// OnDel fires after a Del removes key from every tier.
+	OnDel(key string)
+	// OnScan fires after a pattern-based deletion (e.g.
+	// DeleteKeysByPatternFromRedis) completes.
+	OnScan(pattern string)
+	// OnLoad fires after a GetOrLoad invokes its loader.
+	OnLoad(key string, duration time.Duration, err error)
+	// OnRedisRTT fires after every round trip to Redis (Get/Set/Del/Scan),
+	// so callers can track latency independently of the in-process tiers.
+	OnRedisRTT(op string, duration time.Duration, err error)
+}
+
+type noopObserver struct{}
+
+func (noopObserver) OnHit(string, string)                    {}
+func (noopObserver) OnMiss(string)                           {}
+func (noopObserver) OnSet(string)                            {}
+func (noopObserver) OnDel(string)                            {}
+func (noopObserver) OnScan(string)                           {}
+func (noopObserver) OnLoad(string, time.Duration, error)     {}
+func (noopObserver) OnRedisRTT(string, time.Duration, error) {}
+
+// observer returns hc.Observer, falling back to a no-op so callers (and
+// struct-literal HybridCaches, as the tests build) never need a nil check.
+func (hc *HybridCache) observer() Observer {
+	if hc.Observer == nil {
+		return noopObserver{}
+	}
+	return hc.Observer
+}
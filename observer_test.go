@@ -0,0 +1,48 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	hits   []string
+	misses []string
+	sets   []string
+	dels   []string
+}
+
+func (r *recordingObserver) OnHit(tier, key string)                  { r.hits = append(r.hits, tier+":"+key) }
+func (r *recordingObserver) OnMiss(key string)                       { r.misses = append(r.misses, key) }
+func (r *recordingObserver) OnSet(key string)                        { r.sets = append(r.sets, key) }
+func (r *recordingObserver) OnDel(key string)                        { r.dels = append(r.dels, key) }
+func (r *recordingObserver) OnScan(string)                           {}
+func (r *recordingObserver) OnLoad(string, time.Duration, error)     {}
+func (r *recordingObserver) OnRedisRTT(string, time.Duration, error) {}
+
+func TestObserverCallbacks(t *testing.T) {
+	cache := setupTestCache()
+	defer cache.Close()
+
+	obs := &recordingObserver{}
+	cache.Observer = obs
+
+	key := "observer:key"
+	value := []byte("observer value")
+
+	_, found := cache.Get(key)
+	assert.False(t, found)
+	assert.Equal(t, []string{key}, obs.misses)
+
+	cache.Set(key, value)
+	assert.Equal(t, []string{key}, obs.sets)
+
+	_, found = cache.Get(key)
+	assert.True(t, found)
+	assert.Equal(t, []string{"memory:" + key}, obs.hits)
+
+	cache.Del(key)
+	assert.Equal(t, []string{key}, obs.dels)
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// PrometheusObserver is a ready-made Observer that exposes cache activity
+// as Prometheus metrics: cache_hits_total{tier}, cache_misses_total,
+// cache_operations_total{op}, and a histogram of Redis round-trip time.
+type PrometheusObserver struct {
+	hits         *prometheus.CounterVec
+	misses       prometheus.Counter
+	operations   *prometheus.CounterVec
+	redisRTT     *prometheus.HistogramVec
+	loadDuration prometheus.Histogram
+}
+
+// NewPrometheusObserver creates a PrometheusObserver and registers its
+// metrics with registerer.
+func NewPrometheusObserver(registerer prometheus.Registerer) (*PrometheusObserver, error) {
+	obs := &PrometheusObserver{
+		hits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Number of cache hits, by tier.",
+		}, []string{"tier"}),
+		misses: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Number of cache misses across every tier.",
+		}),
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_operations_total",
+			Help: "Number of cache operations, by op (set/del/scan).",
+		}, []string{"op"}),
+		redisRTT: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cache_redis_rtt_seconds",
+			Help:    "Round-trip latency of Redis calls, by op.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"op"}),
+		loadDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Name:    "cache_load_duration_seconds",
+			Help:    "Latency of GetOrLoad loader invocations.",
+			Buckets: prometheus.DefBuckets,
+		}),
+	}
+
+	for _, collector := range []prometheus.Collector{obs.hits, obs.misses, obs.operations, obs.redisRTT, obs.loadDuration} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return obs, nil
+}
+
+func (obs *PrometheusObserver) OnHit(tier, _ string) {
+	obs.hits.WithLabelValues(tier).Inc()
+}
+
+func (obs *PrometheusObserver) OnMiss(_ string) {
+	obs.misses.Inc()
+}
+
+func (obs *PrometheusObserver) OnSet(_ string) {
+	obs.operations.WithLabelValues("set").Inc()
+}
+
+func (obs *PrometheusObserver) OnDel(_ string) {
+	obs.operations.WithLabelValues("del").Inc()
+}
+
+func (obs *PrometheusObserver) OnScan(_ string) {
+	obs.operations.WithLabelValues("scan").Inc()
+}
+
+func (obs *PrometheusObserver) OnLoad(_ string, duration time.Duration, _ error) {
+	obs.loadDuration.Observe(duration.Seconds())
+}
+
+func (obs *PrometheusObserver) OnRedisRTT(op string, duration time.Duration, _ error) {
+	obs.redisRTT.WithLabelValues(op).Observe(duration.Seconds())
+}
@@ -0,0 +1,16 @@
+package cache
+
+import "context"
+
+// PubSub is a minimal publish/subscribe bus used to propagate cache
+// invalidations across processes that share the same backing store. The
+// default is Redis Pub/Sub (RedisPubSub); non-Redis buses such as NATS, or
+// an in-process channel for tests (InProcPubSub), can be substituted via
+// HybridCacheOption.PubSub.
+type PubSub interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe returns a channel of message payloads published to channel.
+	// The returned channel is closed once ctx is canceled.
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+	Close() error
+}
@@ -0,0 +1,54 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisPubSub is a PubSub implementation backed by Redis Pub/Sub.
+type RedisPubSub struct {
+	client *redis.Client
+}
+
+// NewRedisPubSub wraps an existing Redis client as a PubSub.
+func NewRedisPubSub(client *redis.Client) *RedisPubSub {
+	return &RedisPubSub{client: client}
+}
+
+func (ps *RedisPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	return ps.client.Publish(ctx, channel, payload).Err()
+}
+
+func (ps *RedisPubSub) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	sub := ps.client.Subscribe(ctx, channel)
+	if _, err := sub.Receive(ctx); err != nil {
+		return nil, err
+	}
+
+	out := make(chan []byte)
+	go func() {
+		defer close(out)
+		defer sub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-sub.Channel():
+				if !ok {
+					return
+				}
+				select {
+				case out <- []byte(msg.Payload):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out, nil
+}
+
+func (ps *RedisPubSub) Close() error {
+	return nil
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path"
+	"time"
+)
+
+// ErrScanNotSupported is returned by Store implementations whose backend
+// has no way to enumerate keys by pattern.
+var ErrScanNotSupported = errors.New("cache: store does not support Scan")
+
+// ErrClearNotSupported is returned by Store implementations that have no
+// safe way to evict every entry (e.g. a shared Redis instance, where
+// clearing would mean FLUSHDB and take down every other tenant of it).
+var ErrClearNotSupported = errors.New("cache: store does not support Clear")
+
+// Store is a single cache tier. HybridCache composes an ordered slice of
+// Stores (L1, L2, …): Set writes through every tier and Get checks tiers in
+// order, backfilling earlier tiers when a later tier produces a hit.
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, bool)
+	Set(ctx context.Context, key string, value []byte, expiration time.Duration) error
+	Del(ctx context.Context, key string) error
+	// Scan returns every key matching pattern, or ErrScanNotSupported if the
+	// backend cannot enumerate keys.
+	Scan(ctx context.Context, pattern string) ([]string, error)
+	// Clear evicts every entry in this tier. It is the fallback for
+	// pattern-based invalidation when Scan returns ErrScanNotSupported, and
+	// returns ErrClearNotSupported if the backend has no safe way to do
+	// this.
+	Clear(ctx context.Context) error
+	Close() error
+	// Name identifies the tier for Observer callbacks and logging, e.g.
+	// "memory" or "redis".
+	Name() string
+}
+
+// TagStore is implemented by Store backends that can track tag membership
+// for O(k) group invalidation (see HybridCache.SetWithTags/InvalidateTag).
+// RedisStore and RedisClusterStore implement it; SetWithTags/InvalidateTag
+// return an error if effectiveStores() contains no TagStore.
+type TagStore interface {
+	Store
+	// AddToTags records key as a member of every tagKey (already
+	// namespaced via HybridCache.tagSetKey) in one round trip.
+	AddToTags(ctx context.Context, key string, tagKeys []string) error
+	// TagMembers returns every key recorded under tagKey.
+	TagMembers(ctx context.Context, tagKey string) ([]string, error)
+	// DeleteKeysAndTag pipelines a DEL of every given key plus tagKey
+	// itself, in one round trip.
+	DeleteKeysAndTag(ctx context.Context, keys []string, tagKey string) error
+}
+
+// matchPattern reports whether key matches a glob pattern in path.Match
+// syntax, for Store implementations whose backend has no native pattern
+// matching. Unlike Redis glob matching, '*' and '?' do not match '/'.
+func matchPattern(pattern, key string) (bool, error) {
+	return path.Match(pattern, key)
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/allegro/bigcache/v3"
+)
+
+// BigcacheStore is an in-process Store backed by bigcache. Unlike
+// RistrettoStore, bigcache has no per-key TTL: every entry shares the
+// LifeWindow configured on the underlying *bigcache.BigCache, so the
+// expiration argument passed to Set is ignored.
+type BigcacheStore struct {
+	cache *bigcache.BigCache
+}
+
+// NewBigcacheStore wraps an existing bigcache instance as a Store.
+func NewBigcacheStore(cache *bigcache.BigCache) *BigcacheStore {
+	return &BigcacheStore{cache: cache}
+}
+
+func (s *BigcacheStore) Get(_ context.Context, key string) ([]byte, bool) {
+	value, err := s.cache.Get(key)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (s *BigcacheStore) Set(_ context.Context, key string, value []byte, _ time.Duration) error {
+	return s.cache.Set(key, value)
+}
+
+func (s *BigcacheStore) Del(_ context.Context, key string) error {
+	err := s.cache.Delete(key)
+	if err == bigcache.ErrEntryNotFound {
+		return nil
+	}
+	return err
+}
+
+// Scan walks every live entry and returns the ones whose key matches
+// pattern (see path.Match for the pattern syntax).
+func (s *BigcacheStore) Scan(_ context.Context, pattern string) ([]string, error) {
+	keys := []string{}
+	it := s.cache.Iterator()
+	for it.SetNext() {
+		entry, err := it.Value()
+		if err != nil {
+			return nil, err
+		}
+		matched, err := matchPattern(pattern, entry.Key())
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			keys = append(keys, entry.Key())
+		}
+	}
+	return keys, nil
+}
+
+func (s *BigcacheStore) Clear(_ context.Context) error {
+	return s.cache.Reset()
+}
+
+func (s *BigcacheStore) Close() error {
+	return s.cache.Close()
+}
+
+func (s *BigcacheStore) Name() string {
+	return "bigcache"
+}
@@ -0,0 +1,60 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/coocood/freecache"
+)
+
+// FreecacheStore is an in-process Store backed by freecache.
+type FreecacheStore struct {
+	cache *freecache.Cache
+	ttl   time.Duration
+}
+
+// NewFreecacheStore wraps an existing freecache instance as a Store. ttl is
+// used whenever Set is called with a zero expiration; freecache itself
+// treats an expireSeconds of 0 as "never expire", which would otherwise
+// make an L1 backfill (see HybridCache.GetCtx) permanently cached.
+func NewFreecacheStore(cache *freecache.Cache, ttl time.Duration) *FreecacheStore {
+	return &FreecacheStore{cache: cache, ttl: ttl}
+}
+
+func (s *FreecacheStore) Get(_ context.Context, key string) ([]byte, bool) {
+	value, err := s.cache.Get([]byte(key))
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+func (s *FreecacheStore) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	if expiration == 0 {
+		expiration = s.ttl
+	}
+	return s.cache.Set([]byte(key), value, int(expiration.Seconds()))
+}
+
+func (s *FreecacheStore) Del(_ context.Context, key string) error {
+	s.cache.Del([]byte(key))
+	return nil
+}
+
+// Scan is not supported: freecache keeps no enumerable key index.
+func (s *FreecacheStore) Scan(_ context.Context, _ string) ([]string, error) {
+	return nil, ErrScanNotSupported
+}
+
+func (s *FreecacheStore) Clear(_ context.Context) error {
+	s.cache.Clear()
+	return nil
+}
+
+func (s *FreecacheStore) Close() error {
+	return nil
+}
+
+func (s *FreecacheStore) Name() string {
+	return "freecache"
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisStore is a Store backed by a standalone (or single-node) Redis
+// client.
+type RedisStore struct {
+	client *redis.Client
+	// Observer receives OnRedisRTT callbacks for every call. Defaults to a
+	// no-op; set directly, like HybridCache.Observer.
+	Observer Observer
+	// HashKeysInTracing hashes the cache key before attaching it to spans,
+	// for callers whose keys embed PII.
+	HashKeysInTracing bool
+}
+
+// NewRedisStore wraps an existing Redis client as a Store.
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+func (s *RedisStore) observer() Observer {
+	if s.Observer == nil {
+		return noopObserver{}
+	}
+	return s.Observer
+}
+
+func (s *RedisStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	var data string
+	err := traceRedisCall(ctx, s.observer(), "get", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		var err error
+		data, err = s.client.Get(ctx, key).Result()
+		return err
+	})
+	if err != nil {
+		return nil, false
+	}
+	return []byte(data), true
+}
+
+func (s *RedisStore) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	return traceRedisCall(ctx, s.observer(), "set", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		return s.client.Set(ctx, key, value, expiration).Err()
+	})
+}
+
+func (s *RedisStore) Del(ctx context.Context, key string) error {
+	return traceRedisCall(ctx, s.observer(), "del", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		return s.client.Del(ctx, key).Err()
+	})
+}
+
+// Scan walks the keyspace with a single-node SCAN cursor. It does not work
+// against a Redis Cluster; use RedisClusterStore there instead.
+func (s *RedisStore) Scan(ctx context.Context, pattern string) ([]string, error) {
+	keys := []string{}
+	err := traceRedisCall(ctx, s.observer(), "scan", pattern, s.HashKeysInTracing, func(ctx context.Context) error {
+		cursor := uint64(0)
+		for {
+			scannedKeys, nextCursor, err := s.client.Scan(ctx, cursor, pattern, 100).Result()
+			if err != nil {
+				return err
+			}
+			keys = append(keys, scannedKeys...)
+			cursor = nextCursor
+			if cursor == 0 {
+				return nil
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Clear is not supported: this client is typically shared with other
+// tenants of the same Redis, and FLUSHDB would take all of them down.
+func (s *RedisStore) Clear(_ context.Context) error {
+	return ErrClearNotSupported
+}
+
+func (s *RedisStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisStore) Name() string {
+	return "redis"
+}
+
+// AddToTags records key as a member of every tagKey in one pipelined round
+// trip. It implements TagStore.
+func (s *RedisStore) AddToTags(ctx context.Context, key string, tagKeys []string) error {
+	pipe := s.client.Pipeline()
+	for _, tagKey := range tagKeys {
+		pipe.SAdd(ctx, tagKey, key)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TagMembers returns every key recorded under tagKey. It implements
+// TagStore.
+func (s *RedisStore) TagMembers(ctx context.Context, tagKey string) ([]string, error) {
+	return s.client.SMembers(ctx, tagKey).Result()
+}
+
+// DeleteKeysAndTag pipelines a DEL of every given key plus tagKey itself,
+// in one round trip. It implements TagStore.
+func (s *RedisStore) DeleteKeysAndTag(ctx context.Context, keys []string, tagKey string) error {
+	pipe := s.client.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+	pipe.Del(ctx, tagKey)
+	_, err := pipe.Exec(ctx)
+	return err
+}
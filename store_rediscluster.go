@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisClusterStore is a Store backed by a Redis Cluster client. Unlike
+// RedisStore, Scan fans out across every master shard instead of running a
+// single-node SCAN, which would only see a fraction of the keyspace on a
+// cluster.
+type RedisClusterStore struct {
+	client *redis.ClusterClient
+	// Observer receives OnRedisRTT callbacks for every call. Defaults to a
+	// no-op; set directly, like HybridCache.Observer.
+	Observer Observer
+	// HashKeysInTracing hashes the cache key before attaching it to spans,
+	// for callers whose keys embed PII.
+	HashKeysInTracing bool
+}
+
+// NewRedisClusterStore wraps an existing Redis Cluster client as a Store.
+func NewRedisClusterStore(client *redis.ClusterClient) *RedisClusterStore {
+	return &RedisClusterStore{client: client}
+}
+
+func (s *RedisClusterStore) observer() Observer {
+	if s.Observer == nil {
+		return noopObserver{}
+	}
+	return s.Observer
+}
+
+func (s *RedisClusterStore) Get(ctx context.Context, key string) ([]byte, bool) {
+	var data string
+	err := traceRedisCall(ctx, s.observer(), "get", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		var err error
+		data, err = s.client.Get(ctx, key).Result()
+		return err
+	})
+	if err != nil {
+		return nil, false
+	}
+	return []byte(data), true
+}
+
+func (s *RedisClusterStore) Set(ctx context.Context, key string, value []byte, expiration time.Duration) error {
+	return traceRedisCall(ctx, s.observer(), "set", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		return s.client.Set(ctx, key, value, expiration).Err()
+	})
+}
+
+func (s *RedisClusterStore) Del(ctx context.Context, key string) error {
+	return traceRedisCall(ctx, s.observer(), "del", key, s.HashKeysInTracing, func(ctx context.Context) error {
+		return s.client.Del(ctx, key).Err()
+	})
+}
+
+// Scan fans SCAN out across every master in the cluster and merges the
+// results, since a cluster key can live on any shard.
+func (s *RedisClusterStore) Scan(ctx context.Context, pattern string) ([]string, error) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+
+	err := traceRedisCall(ctx, s.observer(), "scan", pattern, s.HashKeysInTracing, func(ctx context.Context) error {
+		return s.client.ForEachMaster(ctx, func(ctx context.Context, master *redis.Client) error {
+			cursor := uint64(0)
+			for {
+				scannedKeys, nextCursor, err := master.Scan(ctx, cursor, pattern, 100).Result()
+				if err != nil {
+					return err
+				}
+				mu.Lock()
+				keys = append(keys, scannedKeys...)
+				mu.Unlock()
+				cursor = nextCursor
+				if cursor == 0 {
+					return nil
+				}
+			}
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Clear is not supported: this client is typically shared with other
+// tenants of the same cluster, and FLUSHALL would take all of them down.
+func (s *RedisClusterStore) Clear(_ context.Context) error {
+	return ErrClearNotSupported
+}
+
+func (s *RedisClusterStore) Close() error {
+	return s.client.Close()
+}
+
+func (s *RedisClusterStore) Name() string {
+	return "redis-cluster"
+}
+
+// AddToTags records key as a member of every tagKey in one pipelined round
+// trip. It implements TagStore.
+func (s *RedisClusterStore) AddToTags(ctx context.Context, key string, tagKeys []string) error {
+	pipe := s.client.Pipeline()
+	for _, tagKey := range tagKeys {
+		pipe.SAdd(ctx, tagKey, key)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// TagMembers returns every key recorded under tagKey. It implements
+// TagStore.
+func (s *RedisClusterStore) TagMembers(ctx context.Context, tagKey string) ([]string, error) {
+	return s.client.SMembers(ctx, tagKey).Result()
+}
+
+// DeleteKeysAndTag pipelines a DEL of every given key plus tagKey itself,
+// in one round trip. It implements TagStore.
+//
+// Note: in a cluster, keys and tagKey may land on different hash slots, so
+// this single pipeline can span multiple shards; go-redis routes each
+// command to the right node transparently.
+func (s *RedisClusterStore) DeleteKeysAndTag(ctx context.Context, keys []string, tagKey string) error {
+	pipe := s.client.Pipeline()
+	for _, key := range keys {
+		pipe.Del(ctx, key)
+	}
+	pipe.Del(ctx, tagKey)
+	_, err := pipe.Exec(ctx)
+	return err
+}
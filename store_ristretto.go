@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	inMemCLib "github.com/dgraph-io/ristretto"
+)
+
+// RistrettoStore is an in-process Store backed by a ristretto cache.
+type RistrettoStore struct {
+	cache *inMemCLib.Cache
+	ttl   time.Duration
+}
+
+// NewRistrettoStore wraps an existing ristretto cache as a Store. ttl is
+// used whenever Set is called with a zero expiration.
+func NewRistrettoStore(cache *inMemCLib.Cache, ttl time.Duration) *RistrettoStore {
+	return &RistrettoStore{cache: cache, ttl: ttl}
+}
+
+func (s *RistrettoStore) Get(_ context.Context, key string) ([]byte, bool) {
+	value, found := s.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+	return value.([]byte), true
+}
+
+func (s *RistrettoStore) Set(_ context.Context, key string, value []byte, expiration time.Duration) error {
+	if expiration == 0 {
+		expiration = s.ttl
+	}
+	s.cache.SetWithTTL(key, value, 1, expiration) // set value with cost 1
+	s.cache.Wait()                                // wait for value to pass through buffers
+	return nil
+}
+
+func (s *RistrettoStore) Del(_ context.Context, key string) error {
+	s.cache.Del(key)
+	return nil
+}
+
+// Scan is not supported by ristretto, which keeps no enumerable key index.
+func (s *RistrettoStore) Scan(_ context.Context, _ string) ([]string, error) {
+	return nil, ErrScanNotSupported
+}
+
+func (s *RistrettoStore) Clear(_ context.Context) error {
+	s.cache.Clear()
+	return nil
+}
+
+func (s *RistrettoStore) Close() error {
+	s.cache.Close()
+	return nil
+}
+
+func (s *RistrettoStore) Name() string {
+	return "memory"
+}
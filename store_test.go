@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHybridCacheWithCustomStores(t *testing.T) {
+	inMem, err := newTestRistretto()
+	assert.NoError(t, err)
+	redisClient := newTestRedisClient()
+
+	cache := &HybridCache{
+		Prefix:          "test",
+		InMemoryCache:   inMem,
+		Redis:           redisClient,
+		ExpiresInMemory: 5 * time.Minute,
+		ExpiresRedis:    10 * time.Minute,
+		stores: []Store{
+			NewRistrettoStore(inMem, 5*time.Minute),
+			NewRedisStore(redisClient),
+		},
+	}
+	defer cache.Close()
+
+	key := "storeTestKey"
+	value := []byte("storeTestValue")
+	ctx := context.Background()
+
+	cache.SetCtx(ctx, key, value)
+
+	got, found := cache.GetCtx(ctx, key)
+	assert.True(t, found)
+	assert.Equal(t, value, got)
+
+	cache.DelCtx(ctx, key)
+	_, found = cache.GetCtx(ctx, key)
+	assert.False(t, found)
+}
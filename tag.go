@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNoTagStore is returned by SetWithTags/InvalidateTag when
+// effectiveStores() contains no TagStore (e.g. a custom Stores composition
+// with no Redis-backed tier) to record or look up tag membership in.
+var ErrNoTagStore = errors.New("cache: SetWithTags/InvalidateTag require a Redis-backed tier (RedisStore or RedisClusterStore) in the Store chain")
+
+// tagSetKey returns the Redis key of the set tracking every cache key
+// tagged with tag.
+func (hc *HybridCache) tagSetKey(tag string) string {
+	return fmt.Sprintf("%s:tag:%s", hc.Prefix, tag)
+}
+
+// tagStore returns the first configured tier that implements TagStore.
+func (hc *HybridCache) tagStore() (TagStore, error) {
+	for _, store := range hc.effectiveStores() {
+		if ts, ok := store.(TagStore); ok {
+			return ts, nil
+		}
+	}
+	return nil, ErrNoTagStore
+}
+
+// SetWithTags stores value under key like Set, additionally recording key in
+// a tag set per tag via the configured TagStore. InvalidateTag can then
+// evict every key under a tag in one pipelined DEL instead of the O(N) SCAN
+// that DeleteKeysByPatternFromRedis runs on stores that support Scan.
+func (hc *HybridCache) SetWithTags(key string, value []byte, tags ...string) error {
+	return hc.SetWithTagsCtx(context.Background(), key, value, tags...)
+}
+
+// SetWithTagsCtx is the context-aware core of SetWithTags.
+func (hc *HybridCache) SetWithTagsCtx(ctx context.Context, key string, value []byte, tags ...string) error {
+	hc.SetWithTTLCtx(ctx, key, value, hc.ExpiresRedis)
+
+	if len(tags) == 0 {
+		return nil
+	}
+
+	tagStore, err := hc.tagStore()
+	if err != nil {
+		return err
+	}
+
+	tagKeys := make([]string, len(tags))
+	for i, tag := range tags {
+		tagKeys[i] = hc.tagSetKey(tag)
+	}
+	return tagStore.AddToTags(ctx, key, tagKeys)
+}
+
+// InvalidateTag deletes every key recorded under tag, plus the tag set
+// itself, in one pipelined call (O(k), k = members of tag), and publishes
+// an eviction for each key on the invalidation bus so peer processes drop
+// it from their local L1 too.
+func (hc *HybridCache) InvalidateTag(ctx context.Context, tag string) error {
+	tagStore, err := hc.tagStore()
+	if err != nil {
+		return err
+	}
+	tagKey := hc.tagSetKey(tag)
+
+	members, err := tagStore.TagMembers(ctx, tagKey)
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	if err := tagStore.DeleteKeysAndTag(ctx, members, tagKey); err != nil {
+		return err
+	}
+
+	stores := hc.effectiveStores()
+	for _, member := range members {
+		for _, store := range stores {
+			store.Del(ctx, member)
+		}
+		hc.observer().OnDel(member)
+		hc.publishInvalidation(ctx, invalidationMessage{Key: member})
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetWithTagsAndInvalidateTag(t *testing.T) {
+	cache := setupTestCache()
+	defer cache.Close()
+
+	ctx := context.Background()
+	value := []byte("tagged value")
+
+	err := cache.SetWithTagsCtx(ctx, "tag:key1", value, "team-a", "shared")
+	assert.NoError(t, err)
+	err = cache.SetWithTagsCtx(ctx, "tag:key2", value, "team-a")
+	assert.NoError(t, err)
+	err = cache.SetWithTagsCtx(ctx, "tag:key3", value, "team-b")
+	assert.NoError(t, err)
+
+	err = cache.InvalidateTag(ctx, "team-a")
+	assert.NoError(t, err)
+
+	_, found := cache.GetFromRedis(ctx, "tag:key1")
+	assert.False(t, found)
+	_, found = cache.GetFromRedis(ctx, "tag:key2")
+	assert.False(t, found)
+
+	// A key tagged only "team-b" is untouched by invalidating "team-a".
+	got, found := cache.GetFromRedis(ctx, "tag:key3")
+	assert.True(t, found)
+	assert.Equal(t, value, got)
+
+	// The tag set itself is removed along with its members.
+	members, err := cache.Redis.SMembers(ctx, cache.tagSetKey("team-a")).Result()
+	assert.NoError(t, err)
+	assert.Empty(t, members)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var tracer = otel.Tracer("github.com/xplore-run/go-cache")
+
+// spanKeyAttribute returns the span attribute for a cache key. When hash is
+// true the key is SHA-256 hashed first, so callers storing PII in their
+// keys can still get per-key spans without leaking the raw value into a
+// tracing backend.
+func spanKeyAttribute(key string, hash bool) attribute.KeyValue {
+	if !hash {
+		return attribute.String("cache.key", key)
+	}
+	sum := sha256.Sum256([]byte(key))
+	return attribute.String("cache.key.hash", hex.EncodeToString(sum[:]))
+}
+
+// traceRedisCall wraps a single Redis round trip in an OTel span and
+// reports its latency/outcome to obs.OnRedisRTT.
+func traceRedisCall(ctx context.Context, obs Observer, op, key string, hashKeys bool, fn func(ctx context.Context) error) error {
+	ctx, span := tracer.Start(ctx, "cache.redis."+op)
+	span.SetAttributes(attribute.String("cache.op", op), spanKeyAttribute(key, hashKeys))
+	defer span.End()
+
+	start := time.Now()
+	err := fn(ctx)
+	duration := time.Since(start)
+
+	// redis.Nil is go-redis's sentinel for "key not found" on a Get miss,
+	// not a real failure; don't let it flag the span or feed error-rate
+	// metrics/alerts.
+	reportErr := err
+	if errors.Is(reportErr, redis.Nil) {
+		reportErr = nil
+	}
+	if reportErr != nil {
+		span.SetStatus(codes.Error, reportErr.Error())
+	}
+	obs.OnRedisRTT(op, duration, reportErr)
+	return err
+}
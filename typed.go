@@ -0,0 +1,92 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNotFound is returned by TypedCache.Get when the key is not present in
+// any configured cache tier.
+var ErrNotFound = errors.New("cache: key not found")
+
+// TypedCache is a generics façade over HybridCache that marshals values
+// through a Codec instead of requiring callers to hand-roll serialization.
+type TypedCache[T any] struct {
+	hc    *HybridCache
+	codec Codec
+}
+
+// NewTypedCache wraps hc with a typed façade using hc.Codec. If hc.Codec is
+// nil, JSONCodec is used.
+func NewTypedCache[T any](hc *HybridCache) *TypedCache[T] {
+	codec := hc.Codec
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	return &TypedCache[T]{hc: hc, codec: codec}
+}
+
+// Set encodes value with the configured codec and stores it under key.
+func (tc *TypedCache[T]) Set(ctx context.Context, key string, value T) error {
+	return tc.SetWithTTL(ctx, key, value, tc.hc.ExpiresRedis)
+}
+
+// SetWithTTL encodes value and stores it under key with the given expiration.
+func (tc *TypedCache[T]) SetWithTTL(ctx context.Context, key string, value T, expiration time.Duration) error {
+	data, err := tc.codec.Marshal(value)
+	if err != nil {
+		return err
+	}
+	tc.hc.SetWithTTLCtx(ctx, key, data, expiration)
+	return nil
+}
+
+// Get returns the value stored under key, or ErrNotFound if it is absent.
+func (tc *TypedCache[T]) Get(ctx context.Context, key string) (T, error) {
+	var zero T
+	data, found := tc.hc.GetCtx(ctx, key)
+	if !found {
+		return zero, ErrNotFound
+	}
+	var value T
+	if err := tc.codec.Unmarshal(data, &value); err != nil {
+		return zero, err
+	}
+	return value, nil
+}
+
+// Del removes key from every configured cache tier.
+func (tc *TypedCache[T]) Del(ctx context.Context, key string) {
+	tc.hc.DelCtx(ctx, key)
+}
+
+// GetOrLoad returns the cached value for key, delegating to
+// HybridCache.GetOrLoad so typed callers get the same singleflight
+// coalescing, negative caching, and early refresh as byte-level callers.
+//
+// Its values are wrapped in GetOrLoad's internal loadEnvelope format, which
+// is NOT compatible with Set/Get (or TypedCache.Set/Get) on the same key:
+// mixing the two APIs on one key will either unmarshal the raw envelope
+// bytes as T, or make GetOrLoad treat a plain Set'd value as a cache miss
+// and reload it. Pick one API per key.
+func (tc *TypedCache[T]) GetOrLoad(ctx context.Context, key string, loader func(ctx context.Context) (T, error)) (T, error) {
+	data, err := tc.hc.GetOrLoad(ctx, key, tc.hc.ExpiresRedis, func(ctx context.Context) ([]byte, error) {
+		value, err := loader(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return tc.codec.Marshal(value)
+	})
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	var value T
+	if err := tc.codec.Unmarshal(data, &value); err != nil {
+		var zero T
+		return zero, err
+	}
+	return value, nil
+}
@@ -0,0 +1,71 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type typedTestValue struct {
+	Name string
+	Age  int
+}
+
+func TestTypedCacheRoundTrip(t *testing.T) {
+	codecs := map[string]Codec{
+		"json":    JSONCodec{},
+		"gob":     GobCodec{},
+		"msgpack": MsgpackCodec{},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			cache := setupTestCache()
+			defer cache.Close()
+			cache.Codec = codec
+
+			tc := NewTypedCache[typedTestValue](cache)
+			ctx := context.Background()
+			key := "typed:" + name
+
+			err := tc.Set(ctx, key, typedTestValue{Name: "ada", Age: 36})
+			assert.NoError(t, err)
+
+			value, err := tc.Get(ctx, key)
+			assert.NoError(t, err)
+			assert.Equal(t, typedTestValue{Name: "ada", Age: 36}, value)
+
+			tc.Del(ctx, key)
+			_, err = tc.Get(ctx, key)
+			assert.True(t, errors.Is(err, ErrNotFound))
+		})
+	}
+}
+
+func TestTypedCacheGetOrLoad(t *testing.T) {
+	cache := setupTestCache()
+	defer cache.Close()
+
+	tc := NewTypedCache[typedTestValue](cache)
+	ctx := context.Background()
+	key := "typed:getorload"
+
+	loads := 0
+	loader := func(ctx context.Context) (typedTestValue, error) {
+		loads++
+		return typedTestValue{Name: "grace", Age: 40}, nil
+	}
+
+	value, err := tc.GetOrLoad(ctx, key, loader)
+	assert.NoError(t, err)
+	assert.Equal(t, typedTestValue{Name: "grace", Age: 40}, value)
+	assert.Equal(t, 1, loads)
+
+	// second call should be served from cache, loader not called again
+	value, err = tc.GetOrLoad(ctx, key, loader)
+	assert.NoError(t, err)
+	assert.Equal(t, typedTestValue{Name: "grace", Age: 40}, value)
+	assert.Equal(t, 1, loads)
+}